@@ -0,0 +1,73 @@
+/* Copyright © 2017 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+func dataSourceNsxtEdgeCluster() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNsxtEdgeClusterRead,
+
+		Schema: map[string]*schema.Schema{
+			"id":                 getDataSourceIDSchema(),
+			"display_name":       getDataSourceDisplayNameSchema(),
+			"display_name_regex": getDataSourceDisplayNameRegexSchema(),
+			"description":        {Type: schema.TypeString, Computed: true},
+			"tag":                getTagsSchema(),
+			"resource_type":      {Type: schema.TypeString, Computed: true},
+			"revision":           getRevisionSchema(),
+		},
+	}
+}
+
+func dataSourceNsxtEdgeClusterRead(d *schema.ResourceData, m interface{}) error {
+	nsxClient := m.(*NSXClient)
+	objID := d.Get("id").(string)
+	objName := d.Get("display_name").(string)
+	useRegex := d.Get("display_name_regex").(bool)
+
+	var obj manager.EdgeCluster
+	if objID != "" {
+		objGet, _, err := nsxClient.NetworkTransportApi.GetEdgeCluster(nsxClient.Context, objID)
+		if err != nil {
+			return fmt.Errorf("Error while reading edge cluster %s: %v", objID, err)
+		}
+		obj = objGet
+	} else if objName != "" {
+		objList, _, err := nsxClient.NetworkTransportApi.ListEdgeClusters(nsxClient.Context, nil)
+		if err != nil {
+			return fmt.Errorf("Error while reading edge clusters: %v", err)
+		}
+
+		matches := 0
+		for _, objInList := range objList.Results {
+			matched, err := matchesDisplayName(objInList.DisplayName, objName, useRegex)
+			if err != nil {
+				return err
+			}
+			if matched {
+				obj = objInList
+				matches++
+			}
+		}
+		if matches != 1 {
+			return reportDisplayNameMatchError("edge cluster", objName, matches)
+		}
+	} else {
+		return fmt.Errorf("id or display_name must be provided")
+	}
+
+	d.SetId(obj.Id)
+	d.Set("display_name", obj.DisplayName)
+	d.Set("description", obj.Description)
+	setTagsInSchema(d, obj.Tags)
+	d.Set("resource_type", obj.ResourceType)
+	d.Set("revision", obj.Revision)
+
+	return nil
+}
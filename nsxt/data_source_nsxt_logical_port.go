@@ -0,0 +1,83 @@
+/* Copyright © 2017 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+func dataSourceNsxtLogicalPort() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNsxtLogicalPortRead,
+
+		Schema: map[string]*schema.Schema{
+			"id":                   getDataSourceIDSchema(),
+			"display_name":         getDataSourceDisplayNameSchema(),
+			"display_name_regex":   getDataSourceDisplayNameRegexSchema(),
+			"description":          {Type: schema.TypeString, Computed: true},
+			"tag":                  getTagsSchema(),
+			"logical_switch_id":    {Type: schema.TypeString, Computed: true},
+			"admin_state":          {Type: schema.TypeString, Computed: true},
+			"address_binding":      getAddressBindingsSchema(),
+			"switching_profile_id": getSwitchingProfileIdsSchema(),
+			"resource_type":        {Type: schema.TypeString, Computed: true},
+			"revision":             getRevisionSchema(),
+		},
+	}
+}
+
+func dataSourceNsxtLogicalPortRead(d *schema.ResourceData, m interface{}) error {
+	nsxClient := m.(*NSXClient)
+	objID := d.Get("id").(string)
+	objName := d.Get("display_name").(string)
+	useRegex := d.Get("display_name_regex").(bool)
+
+	var obj manager.LogicalPort
+	if objID != "" {
+		objGet, _, err := nsxClient.LogicalSwitchingApi.GetLogicalPort(nsxClient.Context, objID)
+		if err != nil {
+			return fmt.Errorf("Error while reading logical port %s: %v", objID, err)
+		}
+		obj = objGet
+	} else if objName != "" {
+		objList, _, err := nsxClient.LogicalSwitchingApi.ListLogicalPorts(nsxClient.Context, nil)
+		if err != nil {
+			return fmt.Errorf("Error while reading logical ports: %v", err)
+		}
+
+		matches := 0
+		for _, objInList := range objList.Results {
+			matched, err := matchesDisplayName(objInList.DisplayName, objName, useRegex)
+			if err != nil {
+				return err
+			}
+			if matched {
+				obj = objInList
+				matches++
+			}
+		}
+		if matches != 1 {
+			return reportDisplayNameMatchError("logical port", objName, matches)
+		}
+	} else {
+		return fmt.Errorf("id or display_name must be provided")
+	}
+
+	d.SetId(obj.Id)
+	d.Set("display_name", obj.DisplayName)
+	d.Set("description", obj.Description)
+	setTagsInSchema(d, obj.Tags)
+	d.Set("logical_switch_id", obj.LogicalSwitchId)
+	d.Set("admin_state", obj.AdminState)
+	setAddressBindingsInSchema(d, obj.AddressBindings)
+	if err := setSwitchingProfileIdsInSchema(d, nsxClient.APIClient, obj.SwitchingProfileIds); err != nil {
+		return err
+	}
+	d.Set("resource_type", obj.ResourceType)
+	d.Set("revision", obj.Revision)
+
+	return nil
+}
@@ -0,0 +1,83 @@
+/* Copyright © 2017 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/vmware/go-vmware-nsxt/manager"
+)
+
+func dataSourceNsxtLogicalSwitch() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNsxtLogicalSwitchRead,
+
+		Schema: map[string]*schema.Schema{
+			"id":                   getDataSourceIDSchema(),
+			"display_name":         getDataSourceDisplayNameSchema(),
+			"display_name_regex":   getDataSourceDisplayNameRegexSchema(),
+			"description":          {Type: schema.TypeString, Computed: true},
+			"tag":                  getTagsSchema(),
+			"switching_profile_id": getSwitchingProfileIdsSchema(),
+			"address_binding":      getAddressBindingsSchema(),
+			"transport_zone_id":    {Type: schema.TypeString, Computed: true},
+			"admin_state":          {Type: schema.TypeString, Computed: true},
+			"resource_type":        {Type: schema.TypeString, Computed: true},
+			"revision":             getRevisionSchema(),
+		},
+	}
+}
+
+func dataSourceNsxtLogicalSwitchRead(d *schema.ResourceData, m interface{}) error {
+	nsxClient := m.(*NSXClient)
+	objID := d.Get("id").(string)
+	objName := d.Get("display_name").(string)
+	useRegex := d.Get("display_name_regex").(bool)
+
+	var obj manager.LogicalSwitch
+	if objID != "" {
+		objGet, _, err := nsxClient.LogicalSwitchingApi.GetLogicalSwitch(nsxClient.Context, objID)
+		if err != nil {
+			return fmt.Errorf("Error while reading logical switch %s: %v", objID, err)
+		}
+		obj = objGet
+	} else if objName != "" {
+		objList, _, err := nsxClient.LogicalSwitchingApi.ListLogicalSwitches(nsxClient.Context, nil)
+		if err != nil {
+			return fmt.Errorf("Error while reading logical switches: %v", err)
+		}
+
+		matches := 0
+		for _, objInList := range objList.Results {
+			matched, err := matchesDisplayName(objInList.DisplayName, objName, useRegex)
+			if err != nil {
+				return err
+			}
+			if matched {
+				obj = objInList
+				matches++
+			}
+		}
+		if matches != 1 {
+			return reportDisplayNameMatchError("logical switch", objName, matches)
+		}
+	} else {
+		return fmt.Errorf("id or display_name must be provided")
+	}
+
+	d.SetId(obj.Id)
+	d.Set("display_name", obj.DisplayName)
+	d.Set("description", obj.Description)
+	setTagsInSchema(d, obj.Tags)
+	if err := setSwitchingProfileIdsInSchema(d, nsxClient.APIClient, obj.SwitchingProfileIds); err != nil {
+		return err
+	}
+	setAddressBindingsInSchema(d, obj.AddressBindings)
+	d.Set("transport_zone_id", obj.TransportZoneId)
+	d.Set("admin_state", obj.AdminState)
+	d.Set("resource_type", obj.ResourceType)
+	d.Set("revision", obj.Revision)
+
+	return nil
+}
@@ -0,0 +1,34 @@
+/* Copyright © 2017 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// dataSourceNsxtManagerInfo surfaces the NSX version detected (or assumed via
+// NSXT_ASSUME_VERSION) at provider configuration time, so configs can branch
+// on it without each resource re-deriving it.
+func dataSourceNsxtManagerInfo() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNsxtManagerInfoRead,
+
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Description: "The detected NSX manager version",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceNsxtManagerInfoRead(d *schema.ResourceData, m interface{}) error {
+	nsxClient := m.(*NSXClient)
+
+	d.SetId(nsxClient.Version.String())
+	d.Set("version", nsxClient.Version.String())
+
+	return nil
+}
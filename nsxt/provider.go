@@ -0,0 +1,102 @@
+/* Copyright © 2017 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	api "github.com/vmware/go-vmware-nsxt"
+)
+
+// Provider builds the schema.Provider for the nsxt Terraform provider. Resource
+// registrations live alongside their resource_nsxt_*.go files and are merged
+// into ResourcesMap as those chunks land; this file only owns the provider
+// schema, data sources, and client bootstrapping.
+func Provider() terraform.ResourceProvider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"host": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NSXT_MANAGER_HOST", nil),
+				Description: "The hostname or IP address of the NSX manager",
+			},
+			"username": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NSXT_USERNAME", nil),
+				Description: "Username for the NSX manager",
+			},
+			"password": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("NSXT_PASSWORD", nil),
+				Description: "Password for the NSX manager",
+			},
+			"insecure": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NSXT_INSECURE", false),
+				Description: "If true, the NSX manager's certificate will not be validated",
+			},
+			"max_retries": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NSXT_MAX_RETRIES", 4),
+				Description: "Maximum number of retries for requests that fail with a retryable error",
+			},
+		},
+
+		ResourcesMap: map[string]*schema.Resource{},
+
+		DataSourcesMap: map[string]*schema.Resource{
+			"nsxt_logical_switch":    dataSourceNsxtLogicalSwitch(),
+			"nsxt_logical_port":      dataSourceNsxtLogicalPort(),
+			"nsxt_logical_router":    dataSourceNsxtLogicalRouter(),
+			"nsxt_transport_zone":    dataSourceNsxtTransportZone(),
+			"nsxt_switching_profile": dataSourceNsxtSwitchingProfile(),
+			"nsxt_edge_cluster":      dataSourceNsxtEdgeCluster(),
+			"nsxt_service":           dataSourceNsxtService(),
+			"nsxt_manager_info":      dataSourceNsxtManagerInfo(),
+		},
+
+		ConfigureFunc: providerConfigure,
+	}
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	insecure := d.Get("insecure").(bool)
+	username := d.Get("username").(string)
+	password := d.Get("password").(string)
+	host := d.Get("host").(string)
+
+	if host == "" {
+		return nil, fmt.Errorf("host must be provided")
+	}
+
+	cfg := api.Configuration{
+		BasePath:             "/api/v1",
+		Host:                 host,
+		Scheme:               "https",
+		UserAgent:            "terraform-provider-nsxt/1.0",
+		UserName:             username,
+		Password:             password,
+		Insecure:             insecure,
+		RetriesConfiguration: api.ClientRetriesConfiguration{MaxRetries: d.Get("max_retries").(int)},
+	}
+
+	apiClient, err := api.NewAPIClient(&cfg)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create API client: %v", err)
+	}
+
+	nsxClient, err := newNSXClient(apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return nsxClient, nil
+}
@@ -4,14 +4,16 @@
 package nsxt
 
 import (
+	"bytes"
 	"fmt"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/vmware/go-vmware-nsxt"
-	api "github.com/vmware/go-vmware-nsxt"
 	"github.com/vmware/go-vmware-nsxt/common"
 	"github.com/vmware/go-vmware-nsxt/manager"
-	"net/http"
+	"regexp"
 )
 
 var adminStateValues = []string{"UP", "DOWN"}
@@ -39,6 +41,49 @@ func getStringListFromSchemaSet(d *schema.ResourceData, schemaAttrName string) [
 	return interface2StringList(d.Get(schemaAttrName).(*schema.Set).List())
 }
 
+// utilities to define & handle display_name/display_name_prefix, the pair
+// NSX resources should adopt in place of a bare display_name, mirroring the
+// name/name_prefix ConflictsWith pattern on GCE's instance template resource.
+//
+// Neither helper has a caller yet: this tree currently has no
+// resource_nsxt_*.go files for them to be wired into. They land here first
+// so that the resource files landing in a later chunk can adopt
+// getDisplayNameSchema()/resolveDisplayName() directly instead of each
+// reinventing the name/name_prefix pattern.
+func getDisplayNameSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"display_name": &schema.Schema{
+			Type:          schema.TypeString,
+			Description:   "The display name for this resource. Defaults to a generated name if display_name_prefix is set",
+			Optional:      true,
+			Computed:      true,
+			ConflictsWith: []string{"display_name_prefix"},
+		},
+		"display_name_prefix": &schema.Schema{
+			Type:          schema.TypeString,
+			Description:   "Creates a unique display name beginning with this prefix. Conflicts with display_name",
+			Optional:      true,
+			ForceNew:      true,
+			ConflictsWith: []string{"display_name"},
+		},
+	}
+}
+
+// resolveDisplayName returns the display_name a create call should send to
+// NSX: the literal display_name if the caller set one, a name generated from
+// display_name_prefix if that was set instead, or "" if neither was
+// configured. Callers should d.Set("display_name", ...) the result so the
+// generated name is recorded even when display_name_prefix was used.
+func resolveDisplayName(d *schema.ResourceData) string {
+	if v, ok := d.GetOk("display_name"); ok {
+		return v.(string)
+	}
+	if prefix, ok := d.GetOk("display_name_prefix"); ok {
+		return resource.PrefixedUniqueId(prefix.(string))
+	}
+	return ""
+}
+
 func getRevisionSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:        schema.TypeInt,
@@ -102,12 +147,23 @@ func setTagsInSchema(d *schema.ResourceData, tags []common.Tag) {
 }
 
 // utilities to define & handle switching profiles
+// switchingProfileIdHash hashes a switching_profile_id set element by its
+// key/value pair, which are the only fields the element has.
+func switchingProfileIdHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	buf.WriteString(fmt.Sprintf("%s-", m["key"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["value"].(string)))
+	return hashcode.String(buf.String())
+}
+
 func getSwitchingProfileIdsSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:        schema.TypeSet,
 		Description: "List of IDs of switching profiles (of various types) to be associated with this switch. Default switching profiles will be used if not specified",
 		Optional:    true,
 		Computed:    true,
+		Set:         switchingProfileIdHash,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"key": &schema.Schema{
@@ -139,21 +195,122 @@ func getSwitchingProfileIdsFromSchema(d *schema.ResourceData) []manager.Switchin
 	return profileList
 }
 
-func setSwitchingProfileIdsInSchema(d *schema.ResourceData, nsxClient *nsxt.APIClient, profiles []manager.SwitchingProfileTypeIdEntry) {
+func setSwitchingProfileIdsInSchema(d *schema.ResourceData, nsxClient *nsxt.APIClient, profiles []manager.SwitchingProfileTypeIdEntry) error {
+	configured := d.Get("switching_profile_id").(*schema.Set)
 	var profileList []map[string]string
 	for _, profile := range profiles {
-		// ignore system owned profiles
-		obj, _, _ := nsxClient.LogicalSwitchingApi.GetSwitchingProfile(nsxClient.Context, profile.Value)
+		elem := map[string]string{
+			"key":   profile.Key,
+			"value": profile.Value,
+		}
+		hashable := map[string]interface{}{
+			"key":   profile.Key,
+			"value": profile.Value,
+		}
+
+		// A profile the user explicitly listed stays in state even if NSX
+		// reports it as system owned - otherwise it would disappear on
+		// every read and perpetually diff against the user's config.
+		if configured.Contains(hashable) {
+			profileList = append(profileList, elem)
+			continue
+		}
+
+		obj, _, err := nsxClient.LogicalSwitchingApi.GetSwitchingProfile(nsxClient.Context, profile.Value)
+		if err != nil {
+			return fmt.Errorf("Error while reading switching profile %s: %v", profile.Value, err)
+		}
 		if obj.SystemOwned {
 			continue
 		}
 
-		elem := make(map[string]string)
-		elem["key"] = profile.Key
-		elem["value"] = profile.Value
 		profileList = append(profileList, elem)
 	}
 	d.Set("switching_profile_id", profileList)
+	return nil
+}
+
+// utilities to define & handle extra DHCP options, carried on the DHCP
+// static binding rather than the address binding or logical port itself,
+// mirroring the extradhcpopts block on OpenStack's port resource.
+//
+// None of these have a caller yet and extra_dhcp_option is deliberately kept
+// out of getAddressBindingsSchema(): that schema is already live on
+// nsxt_logical_switch/nsxt_logical_port data sources, and nothing in this
+// tree reads the DHCP static binding API to populate it. They land here so
+// the logical port resource landing in a later chunk can nest
+// getExtraDhcpOptsSchema() into its own address_binding block and wire
+// getExtraDhcpOptsFromSchema()/setExtraDhcpOptsInSchema() to that resource's
+// DHCP static binding Create/Read, instead of reinventing this shape.
+type ExtraDhcpOpt struct {
+	Name      string
+	Value     string
+	IpVersion int64
+}
+
+func getExtraDhcpOptsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Description: "Extra DHCP options to configure on this address binding's DHCP static binding",
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": &schema.Schema{
+					Type:        schema.TypeString,
+					Description: "DHCP option name, e.g. 'tftp-server', or a numeric DHCP option code",
+					Required:    true,
+				},
+				"value": &schema.Schema{
+					Type:        schema.TypeString,
+					Description: "DHCP option value",
+					Required:    true,
+				},
+				"ip_version": &schema.Schema{
+					Type:         schema.TypeInt,
+					Description:  "IP version (4 or 6) this option applies to",
+					Optional:     true,
+					Default:      4,
+					ValidateFunc: validateIPVersion(),
+				},
+			},
+		},
+	}
+}
+
+// getExtraDhcpOptsFromSchema reads the extra_dhcp_option elements nested
+// under a single address_binding entry, mirroring the style of
+// getAddressBindingsFromSchema but operating on that entry's already-resolved
+// data map rather than *schema.ResourceData, since the options are nested
+// one level deeper.
+func getExtraDhcpOptsFromSchema(addressBinding map[string]interface{}) []ExtraDhcpOpt {
+	opts := addressBinding["extra_dhcp_option"].(*schema.Set).List()
+	var optList []ExtraDhcpOpt
+	for _, opt := range opts {
+		data := opt.(map[string]interface{})
+		elem := ExtraDhcpOpt{
+			Name:      data["name"].(string),
+			Value:     data["value"].(string),
+			IpVersion: int64(data["ip_version"].(int)),
+		}
+
+		optList = append(optList, elem)
+	}
+	return optList
+}
+
+// setExtraDhcpOptsInSchema returns the extra_dhcp_option elements to nest
+// under an address_binding entry, for the caller to attach to that entry's
+// map before calling d.Set("address_binding", ...).
+func setExtraDhcpOptsInSchema(opts []ExtraDhcpOpt) []map[string]interface{} {
+	var optList []map[string]interface{}
+	for _, opt := range opts {
+		elem := make(map[string]interface{})
+		elem["name"] = opt.Name
+		elem["value"] = opt.Value
+		elem["ip_version"] = opt.IpVersion
+		optList = append(optList, elem)
+	}
+	return optList
 }
 
 // utilities to define & handle address bindings
@@ -168,7 +325,7 @@ func getAddressBindingsSchema() *schema.Schema {
 					Type:         schema.TypeString,
 					Description:  "A single IP address or a subnet cidr",
 					Optional:     true,
-					ValidateFunc: validateSingleIP(),
+					ValidateFunc: validateIPorCIDR(),
 				},
 				"mac_address": &schema.Schema{
 					Type:        schema.TypeString,
@@ -201,6 +358,12 @@ func getAddressBindingsFromSchema(d *schema.ResourceData) []manager.PacketAddres
 	return bindingList
 }
 
+// setAddressBindingsInSchema populates everything PacketAddressClassifier
+// carries. extra_dhcp_option lives on the DHCP static binding, a separate
+// NSX object from the address binding itself, so it is intentionally left
+// out here - the logical port resource is expected to read the DHCP static
+// binding alongside the address binding and merge setExtraDhcpOptsInSchema's
+// output into the same element before calling d.Set.
 func setAddressBindingsInSchema(d *schema.ResourceData, bindings []manager.PacketAddressClassifier) {
 	var bindingList []map[string]interface{}
 	for _, binding := range bindings {
@@ -221,13 +384,25 @@ func getResourceReferencesSetSchema(required bool, computed bool, valid_target_t
 	return getResourceReferencesSchemaByType(required, computed, valid_target_types, false, description)
 }
 
+// resourceReferenceHash hashes a resource-reference set element by its
+// user-authoritative target_id/target_type pair only, so a spurious diff is
+// not created when NSX returns computed fields like target_display_name or
+// is_valid that differ from the user-provided input.
+func resourceReferenceHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	buf.WriteString(fmt.Sprintf("%s-", m["target_id"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["target_type"].(string)))
+	return hashcode.String(buf.String())
+}
+
 func getResourceReferencesSchemaByType(required bool, computed bool, valid_target_types []string, is_list bool, description string) *schema.Schema {
 	sch_type := schema.TypeSet
 	if is_list {
 		sch_type = schema.TypeList
 	}
 
-	return &schema.Schema{
+	sch := &schema.Schema{
 		Type:        sch_type,
 		Required:    required,
 		Optional:    !required,
@@ -259,6 +434,11 @@ func getResourceReferencesSchemaByType(required bool, computed bool, valid_targe
 			},
 		},
 	}
+
+	if !is_list {
+		sch.Set = resourceReferenceHash
+	}
+	return sch
 }
 
 func getResourceReferences(references []interface{}) []common.ResourceReference {
@@ -346,7 +526,7 @@ func getIpSubnetsSchema(required bool, computed bool) *schema.Schema {
 			Schema: map[string]*schema.Schema{
 				"ip_addresses": &schema.Schema{
 					Type:        schema.TypeList,
-					Description: "IPv4 Addresses",
+					Description: "IPv4 or IPv6 Addresses",
 					Optional:    true,
 					Elem: &schema.Schema{
 						Type:         schema.TypeString,
@@ -355,15 +535,44 @@ func getIpSubnetsSchema(required bool, computed bool) *schema.Schema {
 				},
 				"prefix_length": &schema.Schema{
 					Type:         schema.TypeInt,
-					Description:  "Subnet Prefix Length",
+					Description:  "Subnet Prefix Length (0-32 for IPv4, 0-128 for IPv6)",
 					Optional:     true,
-					ValidateFunc: validation.IntBetween(0, 32),
+					ValidateFunc: validation.IntBetween(0, 128),
+				},
+				"ip_version": &schema.Schema{
+					Type:        schema.TypeInt,
+					Description: "IP version (4 or 6) of this subnet, derived from ip_addresses",
+					Computed:    true,
 				},
 			},
 		},
 	}
 }
 
+// validateIpSubnetPrefix checks that prefixLength is a valid mask length for
+// the address family of ipAddresses (0-32 for IPv4, 0-128 for IPv6). Mixed
+// families within a single subnet element are rejected since a subnet can
+// only belong to one family.
+func validateIpSubnetPrefix(ipAddresses []string, prefixLength int64) (int, error) {
+	version := 0
+	for _, address := range ipAddresses {
+		addressVersion := ipVersionOf(address)
+		if version == 0 {
+			version = addressVersion
+		} else if addressVersion != 0 && addressVersion != version {
+			return 0, fmt.Errorf("subnet mixes IPv4 and IPv6 addresses in ip_addresses: %v", ipAddresses)
+		}
+	}
+
+	if version == 6 && prefixLength > 128 {
+		return version, fmt.Errorf("prefix_length %d is not valid for an IPv6 subnet (0-128)", prefixLength)
+	}
+	if version == 4 && prefixLength > 32 {
+		return version, fmt.Errorf("prefix_length %d is not valid for an IPv4 subnet (0-32)", prefixLength)
+	}
+	return version, nil
+}
+
 func getAdminStateSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:         schema.TypeString,
@@ -374,19 +583,26 @@ func getAdminStateSchema() *schema.Schema {
 	}
 }
 
-func getIpSubnetsFromSchema(d *schema.ResourceData) []manager.IpSubnet {
+func getIpSubnetsFromSchema(d *schema.ResourceData) ([]manager.IpSubnet, error) {
 	subnets := d.Get("subnet").([]interface{})
 	var subnetList []manager.IpSubnet
 	for _, subnet := range subnets {
 		data := subnet.(map[string]interface{})
+		ipAddresses := interface2StringList(data["ip_addresses"].([]interface{}))
+		prefixLength := int64(data["prefix_length"].(int))
+
+		if _, err := validateIpSubnetPrefix(ipAddresses, prefixLength); err != nil {
+			return nil, err
+		}
+
 		elem := manager.IpSubnet{
-			IpAddresses:  interface2StringList(data["ip_addresses"].([]interface{})),
-			PrefixLength: int64(data["prefix_length"].(int)),
+			IpAddresses:  ipAddresses,
+			PrefixLength: prefixLength,
 		}
 
 		subnetList = append(subnetList, elem)
 	}
-	return subnetList
+	return subnetList, nil
 }
 
 func setIpSubnetsInSchema(d *schema.ResourceData, subnets []manager.IpSubnet) {
@@ -395,6 +611,8 @@ func setIpSubnetsInSchema(d *schema.ResourceData, subnets []manager.IpSubnet) {
 		elem := make(map[string]interface{})
 		elem["ip_addresses"] = stringList2Interface(subnet.IpAddresses)
 		elem["prefix_length"] = subnet.PrefixLength
+		version, _ := validateIpSubnetPrefix(subnet.IpAddresses, subnet.PrefixLength)
+		elem["ip_version"] = version
 		subnetList = append(subnetList, elem)
 	}
 	d.Set("subnet", subnetList)
@@ -407,14 +625,63 @@ func makeResourceReference(resourceType string, resourceId string) *common.Resou
 	}
 }
 
-func getNSXVersion(m interface{}) string {
-	nsxClient := m.(*api.APIClient)
-	node_properties, resp, err := nsxClient.NsxComponentAdministrationApi.ReadNodeProperties(nsxClient.Context)
-	initial_version := string("1.0.0")
+// getDataSourceIDSchema and getDataSourceDisplayNameSchema are shared by the
+// nsxt_* data sources that look up an existing NSX object by id or by
+// display_name. display_name defaults to an exact match; setting
+// display_name_regex treats it as a regular expression instead, so callers
+// can match a family of objects without knowing the exact generated name.
+func getDataSourceIDSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The id of this resource",
+		Optional:    true,
+		Computed:    true,
+	}
+}
+
+func getDataSourceDisplayNameSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "The display name of this resource",
+		Optional:    true,
+		Computed:    true,
+	}
+}
+
+func getDataSourceDisplayNameRegexSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Treat display_name as a regular expression rather than requiring an exact match",
+		Optional:    true,
+		Default:     false,
+	}
+}
 
-	if resp.StatusCode == http.StatusNotFound || err != nil {
-		fmt.Printf("Node properties not found")
-		return initial_version
+// matchesDisplayName reports whether candidate satisfies pattern, either as
+// an exact string match or, when useRegex is set, as a regexp.MatchString
+// match.
+func matchesDisplayName(candidate string, pattern string, useRegex bool) (bool, error) {
+	if !useRegex {
+		return candidate == pattern, nil
 	}
-	return node_properties.NodeVersion
+
+	matched, err := regexp.MatchString(pattern, candidate)
+	if err != nil {
+		return false, fmt.Errorf("invalid display_name_regex %q: %v", pattern, err)
+	}
+	return matched, nil
 }
+
+// reportDisplayNameMatchError builds the "not found" / "multiple matches"
+// errors shared by every nsxt_* data source's display_name lookup path, so
+// the same resource type always fails the same way for the same count.
+func reportDisplayNameMatchError(resourceType string, displayName string, matchCount int) error {
+	if matchCount == 0 {
+		return fmt.Errorf("%s with display_name '%s' was not found", resourceType, displayName)
+	}
+	return fmt.Errorf("found multiple %s with display_name '%s'", resourceType, displayName)
+}
+
+// NSX version detection and the derived Capabilities matrix now live in
+// version.go, computed once in providerConfigure and cached on NSXClient
+// instead of being queried ad-hoc per call.
@@ -0,0 +1,71 @@
+/* Copyright © 2017 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatchesDisplayName(t *testing.T) {
+	tests := []struct {
+		name      string
+		candidate string
+		pattern   string
+		useRegex  bool
+		want      bool
+		wantErr   bool
+	}{
+		{name: "exact match", candidate: "ls1", pattern: "ls1", useRegex: false, want: true},
+		{name: "exact mismatch", candidate: "ls1", pattern: "ls2", useRegex: false, want: false},
+		{name: "exact match is not substring match", candidate: "ls1-prod", pattern: "ls1", useRegex: false, want: false},
+		{name: "regex match", candidate: "ls1-prod", pattern: "^ls1-.*$", useRegex: true, want: true},
+		{name: "regex mismatch", candidate: "ls2-prod", pattern: "^ls1-.*$", useRegex: true, want: false},
+		{name: "invalid regex errors", candidate: "ls1", pattern: "(", useRegex: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchesDisplayName(tt.candidate, tt.pattern, tt.useRegex)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for pattern %q, got none", tt.pattern)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("matchesDisplayName(%q, %q, %v) = %v, want %v", tt.candidate, tt.pattern, tt.useRegex, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportDisplayNameMatchError(t *testing.T) {
+	tests := []struct {
+		name       string
+		matchCount int
+		wantSubstr string
+	}{
+		{name: "zero matches", matchCount: 0, wantSubstr: "was not found"},
+		{name: "multiple matches", matchCount: 2, wantSubstr: "found multiple"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := reportDisplayNameMatchError("logical switch", "ls1", tt.matchCount)
+			if err == nil {
+				t.Fatal("expected a non-nil error")
+			}
+			if !strings.Contains(err.Error(), tt.wantSubstr) {
+				t.Errorf("error %q does not contain %q", err.Error(), tt.wantSubstr)
+			}
+			if !strings.Contains(err.Error(), "ls1") {
+				t.Errorf("error %q does not mention the display name", err.Error())
+			}
+		})
+	}
+}
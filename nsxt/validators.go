@@ -0,0 +1,72 @@
+/* Copyright © 2017 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	"net"
+)
+
+// validateSingleIP ensures the given value is a single IPv4 or IPv6 address
+// (no prefix/mask).
+func validateSingleIP() func(v interface{}, k string) (ws []string, errors []error) {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		ip := net.ParseIP(v.(string))
+		if ip == nil {
+			errors = append(errors, fmt.Errorf("%q is not a valid IP address: %s", k, v))
+		}
+		return
+	}
+}
+
+// validateIPorCIDR ensures the given value is either a single IPv4/IPv6
+// address or an IPv4/IPv6 CIDR block, matching schema fields (like
+// address_binding's ip_address) that are documented to accept either form.
+func validateIPorCIDR() func(v interface{}, k string) (ws []string, errors []error) {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		if net.ParseIP(value) != nil {
+			return
+		}
+		if _, _, err := net.ParseCIDR(value); err == nil {
+			return
+		}
+		errors = append(errors, fmt.Errorf("%q is not a valid IP address or CIDR: %s", k, value))
+		return
+	}
+}
+
+// validateIPVersion ensures the given value is 4 or 6.
+func validateIPVersion() func(v interface{}, k string) (ws []string, errors []error) {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		version := v.(int)
+		if version != 4 && version != 6 {
+			errors = append(errors, fmt.Errorf("%q must be 4 or 6, got: %d", k, version))
+		}
+		return
+	}
+}
+
+// ipVersionOf returns 4 or 6 for a valid IPv4/IPv6 address or CIDR block, or
+// 0 if address is empty or unparseable. Used to populate the computed
+// ip_version field alongside a user-supplied address.
+func ipVersionOf(address string) int {
+	if address == "" {
+		return 0
+	}
+
+	ip := net.ParseIP(address)
+	if ip == nil {
+		if parsedIP, _, err := net.ParseCIDR(address); err == nil {
+			ip = parsedIP
+		}
+	}
+	if ip == nil {
+		return 0
+	}
+	if ip.To4() != nil {
+		return 4
+	}
+	return 6
+}
@@ -0,0 +1,120 @@
+/* Copyright © 2017 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"testing"
+)
+
+func TestValidateIPorCIDR(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "ipv4 address", value: "10.0.0.1", wantErr: false},
+		{name: "ipv4 cidr", value: "10.0.0.0/24", wantErr: false},
+		{name: "ipv6 address", value: "2001:db8::1", wantErr: false},
+		{name: "ipv6 cidr", value: "2001:db8::/32", wantErr: false},
+		{name: "not an address", value: "not-an-ip", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errors := validateIPorCIDR()(tt.value, "ip_address")
+			if tt.wantErr && len(errors) == 0 {
+				t.Errorf("expected an error for %q, got none", tt.value)
+			}
+			if !tt.wantErr && len(errors) != 0 {
+				t.Errorf("expected no error for %q, got %v", tt.value, errors)
+			}
+		})
+	}
+}
+
+func TestIpVersionOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		want    int
+	}{
+		{name: "ipv4 address", address: "10.0.0.1", want: 4},
+		{name: "ipv4 cidr", address: "10.0.0.0/24", want: 4},
+		{name: "ipv6 address", address: "2001:db8::1", want: 6},
+		{name: "ipv6 cidr", address: "2001:db8::/32", want: 6},
+		{name: "empty", address: "", want: 0},
+		{name: "garbage", address: "not-an-ip", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ipVersionOf(tt.address); got != tt.want {
+				t.Errorf("ipVersionOf(%q) = %d, want %d", tt.address, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateIpSubnetPrefix(t *testing.T) {
+	tests := []struct {
+		name         string
+		ipAddresses  []string
+		prefixLength int64
+		wantVersion  int
+		wantErr      bool
+	}{
+		{
+			name:         "ipv4 prefix within bounds",
+			ipAddresses:  []string{"10.0.0.1"},
+			prefixLength: 24,
+			wantVersion:  4,
+			wantErr:      false,
+		},
+		{
+			name:         "ipv4 prefix exceeds 32",
+			ipAddresses:  []string{"10.0.0.1"},
+			prefixLength: 33,
+			wantVersion:  4,
+			wantErr:      true,
+		},
+		{
+			name:         "ipv6 prefix between 33 and 128 is valid",
+			ipAddresses:  []string{"2001:db8::1"},
+			prefixLength: 64,
+			wantVersion:  6,
+			wantErr:      false,
+		},
+		{
+			name:         "ipv6 prefix exceeds 128",
+			ipAddresses:  []string{"2001:db8::1"},
+			prefixLength: 129,
+			wantVersion:  6,
+			wantErr:      true,
+		},
+		{
+			name:         "mixed v4/v6 addresses in one subnet is rejected",
+			ipAddresses:  []string{"10.0.0.1", "2001:db8::1"},
+			prefixLength: 24,
+			wantVersion:  0,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := validateIpSubnetPrefix(tt.ipAddresses, tt.prefixLength)
+			if tt.wantErr && err == nil {
+				t.Fatalf("expected an error, got none")
+			}
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if version != tt.wantVersion {
+					t.Errorf("version = %d, want %d", version, tt.wantVersion)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+/* Copyright © 2017 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"fmt"
+	api "github.com/vmware/go-vmware-nsxt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// assumeVersionEnvVar overrides NSX version detection, for offline planning
+// (e.g. CI runs with no reachable manager) against a known manager version.
+const assumeVersionEnvVar = "NSXT_ASSUME_VERSION"
+
+// NSXVersion is a parsed NSX manager version, comparable for feature gating.
+// NSX manager version strings are not strict semver (e.g. "2.5.1.0.0.123"),
+// so only the major.minor.patch triplet is considered.
+type NSXVersion struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+func parseNSXVersion(raw string) (NSXVersion, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) < 3 {
+		return NSXVersion{}, fmt.Errorf("invalid NSX version %q: expected at least major.minor.patch", raw)
+	}
+
+	var nums [3]int
+	for i := 0; i < 3; i++ {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return NSXVersion{}, fmt.Errorf("invalid NSX version %q: %v", raw, err)
+		}
+		nums[i] = n
+	}
+
+	return NSXVersion{Major: nums[0], Minor: nums[1], Patch: nums[2]}, nil
+}
+
+func (v NSXVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// AtLeast reports whether v is greater than or equal to the major.minor.patch
+// triplet described by other (e.g. "2.4.0").
+func (v NSXVersion) AtLeast(other string) bool {
+	o, err := parseNSXVersion(other)
+	if err != nil {
+		return false
+	}
+
+	if v.Major != o.Major {
+		return v.Major > o.Major
+	}
+	if v.Minor != o.Minor {
+		return v.Minor > o.Minor
+	}
+	return v.Patch >= o.Patch
+}
+
+// Capabilities is the set of NSX manager features gated by version, computed
+// once when the provider is configured and cached on NSXClient so resource
+// schemas don't re-detect the version on every CRUD call.
+type Capabilities struct {
+	Version NSXVersion
+
+	SupportsMACLearning    bool
+	SupportsBFDPerPeer     bool
+	SupportsPolicyAPI      bool
+	SupportsIPv6RouterPort bool
+}
+
+// AtLeast reports whether the detected NSX version is at least version, for
+// one-off feature checks that don't warrant their own named capability, e.g.
+// nsxCapabilities(m).AtLeast("2.4.0").
+func (c Capabilities) AtLeast(version string) bool {
+	return c.Version.AtLeast(version)
+}
+
+func capabilitiesForVersion(v NSXVersion) Capabilities {
+	return Capabilities{
+		Version:                v,
+		SupportsMACLearning:    v.AtLeast("2.0.0"),
+		SupportsBFDPerPeer:     v.AtLeast("2.4.0"),
+		SupportsPolicyAPI:      v.AtLeast("2.4.0"),
+		SupportsIPv6RouterPort: v.AtLeast("2.4.0"),
+	}
+}
+
+// nsxCapabilities retrieves the capability matrix cached on the provider's
+// client, for use in resource schemas, e.g. to ConflictsWith or mark a field
+// Computed based on nsxCapabilities(m).SupportsBFDPerPeer.
+func nsxCapabilities(m interface{}) Capabilities {
+	return m.(*NSXClient).Capabilities
+}
+
+// requireCapability returns a plan-time error for a field that needs an NSX
+// version newer than what was detected, e.g.
+// requireCapability(m, nsxCapabilities(m).SupportsBFDPerPeer, "bfd_peer", "2.4.0").
+func requireCapability(m interface{}, supported bool, field string, minVersion string) error {
+	if supported {
+		return nil
+	}
+	return fmt.Errorf("field %s requires NSX >= %s, detected %s", field, minVersion, nsxCapabilities(m).Version)
+}
+
+// NSXClient wraps the generated go-vmware-nsxt API client with the detected
+// NSX manager Version and the Capabilities derived from it. Both are
+// computed once in providerConfigure; resource and data source Read/Create
+// functions receive *NSXClient as their m interface{} and can access the
+// embedded *api.APIClient exactly as before.
+type NSXClient struct {
+	*api.APIClient
+
+	Version      NSXVersion
+	Capabilities Capabilities
+}
+
+func newNSXClient(apiClient *api.APIClient) (*NSXClient, error) {
+	version, err := detectNSXVersion(apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NSXClient{
+		APIClient:    apiClient,
+		Version:      version,
+		Capabilities: capabilitiesForVersion(version),
+	}, nil
+}
+
+// detectNSXVersion returns the NSX manager version, honoring the
+// NSXT_ASSUME_VERSION override before falling back to querying node
+// properties from the manager itself.
+func detectNSXVersion(apiClient *api.APIClient) (NSXVersion, error) {
+	if assumed := os.Getenv(assumeVersionEnvVar); assumed != "" {
+		return parseNSXVersion(assumed)
+	}
+
+	nodeProperties, resp, err := apiClient.NsxComponentAdministrationApi.ReadNodeProperties(apiClient.Context)
+	if err != nil {
+		return NSXVersion{}, fmt.Errorf("Error while reading NSX manager version: %v", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return NSXVersion{}, fmt.Errorf("Error while reading NSX manager version: node properties not found")
+	}
+
+	return parseNSXVersion(nodeProperties.NodeVersion)
+}
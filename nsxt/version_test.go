@@ -0,0 +1,122 @@
+/* Copyright © 2017 VMware, Inc. All Rights Reserved.
+   SPDX-License-Identifier: MPL-2.0 */
+
+package nsxt
+
+import (
+	"testing"
+)
+
+func TestParseNSXVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    NSXVersion
+		wantErr bool
+	}{
+		{name: "plain triplet", raw: "2.4.0", want: NSXVersion{2, 4, 0}},
+		{name: "manager-style version with extra components", raw: "2.5.1.0.0.14940283", want: NSXVersion{2, 5, 1}},
+		{name: "too few components", raw: "2.4", wantErr: true},
+		{name: "empty string", raw: "", wantErr: true},
+		{name: "non-numeric major", raw: "x.4.0", wantErr: true},
+		{name: "non-numeric patch", raw: "2.4.y", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNSXVersion(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error parsing %q, got none", tt.raw)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error parsing %q: %v", tt.raw, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseNSXVersion(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNSXVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		name    string
+		version NSXVersion
+		other   string
+		want    bool
+	}{
+		{name: "equal versions", version: NSXVersion{2, 4, 0}, other: "2.4.0", want: true},
+		{name: "higher major wins regardless of minor/patch", version: NSXVersion{3, 0, 0}, other: "2.9.9", want: true},
+		{name: "lower major loses regardless of minor/patch", version: NSXVersion{1, 9, 9}, other: "2.0.0", want: false},
+		{name: "same major, higher minor", version: NSXVersion{2, 5, 0}, other: "2.4.0", want: true},
+		{name: "same major, lower minor", version: NSXVersion{2, 3, 0}, other: "2.4.0", want: false},
+		{name: "same major/minor, higher patch", version: NSXVersion{2, 4, 1}, other: "2.4.0", want: true},
+		{name: "same major/minor, lower patch", version: NSXVersion{2, 4, 0}, other: "2.4.1", want: false},
+		{name: "invalid other defaults to false", version: NSXVersion{9, 9, 9}, other: "not-a-version", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.version.AtLeast(tt.other); got != tt.want {
+				t.Errorf("%+v.AtLeast(%q) = %v, want %v", tt.version, tt.other, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCapabilitiesForVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version NSXVersion
+		want    Capabilities
+	}{
+		{
+			name:    "pre-2.0 has no capabilities",
+			version: NSXVersion{1, 9, 9},
+			want: Capabilities{
+				Version:                NSXVersion{1, 9, 9},
+				SupportsMACLearning:    false,
+				SupportsBFDPerPeer:     false,
+				SupportsPolicyAPI:      false,
+				SupportsIPv6RouterPort: false,
+			},
+		},
+		{
+			name:    "2.0-2.3 only has MAC learning",
+			version: NSXVersion{2, 3, 0},
+			want: Capabilities{
+				Version:                NSXVersion{2, 3, 0},
+				SupportsMACLearning:    true,
+				SupportsBFDPerPeer:     false,
+				SupportsPolicyAPI:      false,
+				SupportsIPv6RouterPort: false,
+			},
+		},
+		{
+			name:    "2.4+ has everything",
+			version: NSXVersion{2, 4, 0},
+			want: Capabilities{
+				Version:                NSXVersion{2, 4, 0},
+				SupportsMACLearning:    true,
+				SupportsBFDPerPeer:     true,
+				SupportsPolicyAPI:      true,
+				SupportsIPv6RouterPort: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := capabilitiesForVersion(tt.version)
+			if got != tt.want {
+				t.Errorf("capabilitiesForVersion(%+v) = %+v, want %+v", tt.version, got, tt.want)
+			}
+			if got.AtLeast("2.4.0") != tt.want.SupportsPolicyAPI {
+				t.Errorf("Capabilities.AtLeast(\"2.4.0\") = %v, want %v", got.AtLeast("2.4.0"), tt.want.SupportsPolicyAPI)
+			}
+		})
+	}
+}